@@ -0,0 +1,410 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BorderStyle selects how the overlay border is animated.
+type BorderStyle string
+
+const (
+	BorderStyleSolid   BorderStyle = "solid"
+	BorderStyleDashed  BorderStyle = "dashed"
+	BorderStylePulsing BorderStyle = "pulsing"
+)
+
+// ParseBorderStyle parses a --border-style flag value or border.style config
+// field.
+func ParseBorderStyle(s string) (BorderStyle, error) {
+	switch style := BorderStyle(s); style {
+	case BorderStyleSolid, BorderStyleDashed, BorderStylePulsing:
+		return style, nil
+	default:
+		return "", fmt.Errorf("invalid border style %q: must be solid, dashed, or pulsing", s)
+	}
+}
+
+// Duration is a time.Duration that reads from and writes to config.toml as
+// a time.ParseDuration string (e.g. "5s") instead of a bare integer of
+// nanoseconds, so config.toml stays human-editable.
+type Duration time.Duration
+
+func (d Duration) Duration() time.Duration { return time.Duration(d) }
+
+func (d Duration) String() string { return time.Duration(d).String() }
+
+// BorderConfig configures the overlay's color, thickness, and animation
+// style. Color is overridden per detector by DetectorConfig.Color so
+// viewers can tell platforms apart at a glance; see Config.ColorForDetector.
+type BorderConfig struct {
+	Color   string // hex, e.g. "#ff0000"
+	WidthPx int
+	Style   BorderStyle
+}
+
+// RGB parses Color as a 6-digit hex string.
+func (c BorderConfig) RGB() (r, g, b byte, err error) {
+	return ParseHexColor(c.Color)
+}
+
+// ParseHexColor parses a "#rrggbb" or "rrggbb" string into its components.
+func ParseHexColor(s string) (r, g, b byte, err error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return 0, 0, 0, fmt.Errorf("invalid color %q: expected 6 hex digits (RRGGBB)", s)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid color %q: %w", s, err)
+	}
+	return byte(v >> 16), byte(v >> 8), byte(v), nil
+}
+
+// PollConfig configures how often polling StreamDetectors (YouTube, Twitch,
+// Kick, RTMP) check for a live status change.
+type PollConfig struct {
+	Interval         Duration
+	MaxInterval      Duration // cap for exponential backoff; YouTube only
+	BackoffOnError   bool     // back off on quota/rate-limit/5xx errors; YouTube only
+	DailyQuotaBudget int      // YouTube Data API units/day before polling pauses
+}
+
+// DetectorConfig enables and configures one StreamDetector backend. Only the
+// fields relevant to Name are read; the rest are ignored.
+type DetectorConfig struct {
+	Name    string // "youtube", "obs", "twitch", "kick", or "rtmp"
+	Enabled bool
+	Color   string // hex override for this detector's border color
+
+	// Twitch
+	ClientID     string
+	ClientSecret string
+	UserLogin    string
+
+	// Kick
+	ChannelSlug string
+
+	// RTMP
+	URL string
+}
+
+// Config is LiveFrame's runtime configuration, loaded from
+// ~/.liveframe/config.toml and overridable with CLI flags.
+type Config struct {
+	Border    BorderConfig
+	Poll      PollConfig
+	Detectors []DetectorConfig
+}
+
+// DefaultConfig returns the config LiveFrame ran with before config.toml
+// existed: a 2px solid red border, polling YouTube every 5 seconds.
+func DefaultConfig() Config {
+	return Config{
+		Border: BorderConfig{Color: "#ff0000", WidthPx: 2, Style: BorderStyleSolid},
+		Poll: PollConfig{
+			Interval:         Duration(5 * time.Second),
+			MaxInterval:      Duration(5 * time.Minute),
+			BackoffOnError:   true,
+			DailyQuotaBudget: 10000, // YouTube Data API's default daily project quota
+		},
+	}
+}
+
+// ConfigFile returns the path to the config file.
+func ConfigFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(home, ".liveframe", "config.toml"), nil
+}
+
+// LoadConfig loads Config from path, overlaying it onto DefaultConfig. A
+// missing file is the expected way to run with defaults, so callers should
+// not treat it as fatal.
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	if err := decodeConfigTOML(b, &cfg); err != nil {
+		return cfg, fmt.Errorf("error parsing config file: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// decodeConfigTOML parses a minimal subset of TOML sufficient for
+// config.toml: [border]/[poll] tables, a [[detectors]] array of tables, and
+// string/bool/integer values. There's no need for a general-purpose TOML
+// library for one small, fixed schema.
+func decodeConfigTOML(b []byte, cfg *Config) error {
+	section := ""
+	var detector *DetectorConfig
+
+	for i, raw := range strings.Split(string(b), "\n") {
+		lineNo := i + 1
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") && strings.HasSuffix(line, "]]") {
+			name := strings.TrimSpace(line[2 : len(line)-2])
+			if name != "detectors" {
+				return fmt.Errorf("line %d: unknown table array [[%s]]", lineNo, name)
+			}
+			cfg.Detectors = append(cfg.Detectors, DetectorConfig{})
+			detector = &cfg.Detectors[len(cfg.Detectors)-1]
+			section = name
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			detector = nil
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("line %d: expected key = value, got %q", lineNo, line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		var err error
+		switch section {
+		case "border":
+			err = setBorderField(&cfg.Border, key, value)
+		case "poll":
+			err = setPollField(&cfg.Poll, key, value)
+		case "detectors":
+			if detector == nil {
+				err = fmt.Errorf("key %q outside of a [[detectors]] table", key)
+			} else {
+				err = setDetectorField(detector, key, value)
+			}
+		default:
+			err = fmt.Errorf("key %q outside of a known table", key)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+
+	return nil
+}
+
+func setBorderField(b *BorderConfig, key, value string) error {
+	switch key {
+	case "color":
+		s, err := tomlString(value)
+		if err != nil {
+			return err
+		}
+		b.Color = s
+	case "width_px":
+		n, err := tomlInt(value)
+		if err != nil {
+			return err
+		}
+		b.WidthPx = n
+	case "style":
+		s, err := tomlString(value)
+		if err != nil {
+			return err
+		}
+		style, err := ParseBorderStyle(s)
+		if err != nil {
+			return err
+		}
+		b.Style = style
+	default:
+		return fmt.Errorf("unknown border.%s", key)
+	}
+	return nil
+}
+
+func setPollField(p *PollConfig, key, value string) error {
+	switch key {
+	case "interval":
+		d, err := tomlDuration(value)
+		if err != nil {
+			return err
+		}
+		p.Interval = d
+	case "max_interval":
+		d, err := tomlDuration(value)
+		if err != nil {
+			return err
+		}
+		p.MaxInterval = d
+	case "backoff_on_error":
+		v, err := tomlBool(value)
+		if err != nil {
+			return err
+		}
+		p.BackoffOnError = v
+	case "daily_quota_budget":
+		n, err := tomlInt(value)
+		if err != nil {
+			return err
+		}
+		p.DailyQuotaBudget = n
+	default:
+		return fmt.Errorf("unknown poll.%s", key)
+	}
+	return nil
+}
+
+func setDetectorField(d *DetectorConfig, key, value string) error {
+	if key == "enabled" {
+		v, err := tomlBool(value)
+		if err != nil {
+			return err
+		}
+		d.Enabled = v
+		return nil
+	}
+
+	s, err := tomlString(value)
+	if err != nil {
+		return err
+	}
+	switch key {
+	case "name":
+		d.Name = s
+	case "color":
+		d.Color = s
+	case "client_id":
+		d.ClientID = s
+	case "client_secret":
+		d.ClientSecret = s
+	case "user_login":
+		d.UserLogin = s
+	case "channel_slug":
+		d.ChannelSlug = s
+	case "url":
+		d.URL = s
+	default:
+		return fmt.Errorf("unknown detectors.%s", key)
+	}
+	return nil
+}
+
+func tomlString(value string) (string, error) {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return "", fmt.Errorf("expected a quoted string, got %q", value)
+	}
+	return value[1 : len(value)-1], nil
+}
+
+func tomlBool(value string) (bool, error) {
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("expected true or false, got %q", value)
+	}
+}
+
+func tomlInt(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("expected an integer, got %q: %w", value, err)
+	}
+	return n, nil
+}
+
+func tomlDuration(value string) (Duration, error) {
+	s, err := tomlString(value)
+	if err != nil {
+		return 0, err
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	return Duration(d), nil
+}
+
+// EncodeConfigTOML renders cfg in the same config.toml format LoadConfig
+// reads, for --print-config.
+func EncodeConfigTOML(cfg Config) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "[border]\n")
+	fmt.Fprintf(&sb, "color = %q\n", cfg.Border.Color)
+	fmt.Fprintf(&sb, "width_px = %d\n", cfg.Border.WidthPx)
+	fmt.Fprintf(&sb, "style = %q\n", cfg.Border.Style)
+
+	fmt.Fprintf(&sb, "\n[poll]\n")
+	fmt.Fprintf(&sb, "interval = %q\n", cfg.Poll.Interval.String())
+	fmt.Fprintf(&sb, "max_interval = %q\n", cfg.Poll.MaxInterval.String())
+	fmt.Fprintf(&sb, "backoff_on_error = %t\n", cfg.Poll.BackoffOnError)
+	fmt.Fprintf(&sb, "daily_quota_budget = %d\n", cfg.Poll.DailyQuotaBudget)
+
+	for _, d := range cfg.Detectors {
+		fmt.Fprintf(&sb, "\n[[detectors]]\n")
+		fmt.Fprintf(&sb, "name = %q\n", d.Name)
+		fmt.Fprintf(&sb, "enabled = %t\n", d.Enabled)
+		if d.Color != "" {
+			fmt.Fprintf(&sb, "color = %q\n", d.Color)
+		}
+		if d.ClientID != "" {
+			fmt.Fprintf(&sb, "client_id = %q\n", d.ClientID)
+		}
+		if d.ClientSecret != "" {
+			fmt.Fprintf(&sb, "client_secret = %q\n", d.ClientSecret)
+		}
+		if d.UserLogin != "" {
+			fmt.Fprintf(&sb, "user_login = %q\n", d.UserLogin)
+		}
+		if d.ChannelSlug != "" {
+			fmt.Fprintf(&sb, "channel_slug = %q\n", d.ChannelSlug)
+		}
+		if d.URL != "" {
+			fmt.Fprintf(&sb, "url = %q\n", d.URL)
+		}
+	}
+
+	return sb.String()
+}
+
+// ColorForDetector returns the border color configured for the detector
+// named name, falling back to fallback's built-in default if it has no
+// config entry or no color override.
+func (c Config) ColorForDetector(name string, fallback Platform) (r, g, b byte) {
+	for _, d := range c.Detectors {
+		if d.Name == name && d.Color != "" {
+			if r, g, b, err := ParseHexColor(d.Color); err == nil {
+				return r, g, b
+			}
+		}
+	}
+	return fallback.BorderColor()
+}
+
+// DetectorConfigFor returns the config entry for the given detector name,
+// if one exists and is enabled.
+func (c Config) DetectorConfigFor(name string) (DetectorConfig, bool) {
+	for _, d := range c.Detectors {
+		if d.Name == name && d.Enabled {
+			return d, true
+		}
+	}
+	return DetectorConfig{}, false
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// Platform identifies which streaming service or local source a
+// StreamEvent came from.
+type Platform string
+
+const (
+	PlatformYouTube Platform = "youtube"
+	PlatformTwitch  Platform = "twitch"
+	PlatformKick    Platform = "kick"
+	PlatformRTMP    Platform = "rtmp"
+	PlatformOBS     Platform = "obs"
+)
+
+// BorderColor returns the RGB color LiveFrame draws the border in when this
+// platform is live, so viewers can tell platforms apart at a glance.
+func (p Platform) BorderColor() (r, g, b byte) {
+	switch p {
+	case PlatformYouTube:
+		return 255, 0, 0
+	case PlatformTwitch:
+		return 145, 70, 255
+	case PlatformKick:
+		return 83, 252, 24
+	case PlatformRTMP:
+		return 255, 165, 0
+	case PlatformOBS:
+		return 79, 69, 221
+	default:
+		return 255, 0, 0
+	}
+}
+
+// StreamEvent reports the current live status for one platform.
+type StreamEvent struct {
+	Platform    Platform
+	IsLive      bool
+	Title       string
+	ViewerCount int
+}
+
+// LiveState tracks the last-known live status per platform across a merged
+// StreamEvent stream, so that e.g. Twitch going offline doesn't hide the
+// border while YouTube is still live. It is not safe for concurrent use;
+// callers should keep it on the goroutine draining the event channel.
+type LiveState struct {
+	live map[Platform]bool
+}
+
+// NewLiveState returns an empty LiveState with every platform considered
+// offline.
+func NewLiveState() *LiveState {
+	return &LiveState{live: make(map[Platform]bool)}
+}
+
+// Update records event's status and reports whether any tracked platform is
+// now live, plus which platform to draw the border with. If event.IsLive,
+// that platform is used; otherwise, if another platform is still live, one
+// of those is used instead (which one is unspecified when more than one
+// qualifies).
+func (s *LiveState) Update(event StreamEvent) (visible bool, livePlatform Platform) {
+	s.live[event.Platform] = event.IsLive
+
+	if event.IsLive {
+		return true, event.Platform
+	}
+
+	for platform, isLive := range s.live {
+		if isLive {
+			visible = true
+			livePlatform = platform
+		}
+	}
+	return visible, livePlatform
+}
+
+// StreamDetector watches a single streaming platform (or local ingest) and
+// reports live-status changes on the returned channel until ctx is
+// canceled, at which point the channel is closed.
+type StreamDetector interface {
+	Start(ctx context.Context) <-chan StreamEvent
+}
+
+// fanInDetectors starts every detector and merges their events onto a single
+// channel, which is closed once all detectors have closed theirs (i.e. once
+// ctx is canceled).
+func fanInDetectors(ctx context.Context, detectors []StreamDetector) <-chan StreamEvent {
+	out := make(chan StreamEvent)
+
+	var wg sync.WaitGroup
+	for _, d := range detectors {
+		wg.Add(1)
+		go func(d StreamDetector) {
+			defer wg.Done()
+			forwardEvents(ctx, d, out)
+		}(d)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// forwardEvents copies d's events onto out until d's channel closes or ctx
+// is canceled.
+func forwardEvents(ctx context.Context, d StreamDetector, out chan<- StreamEvent) {
+	for event := range d.Start(ctx) {
+		select {
+		case out <- event:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
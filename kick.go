@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const kickChannelURLFormat = "https://kick.com/api/v2/channels/%s"
+
+// KickDetector is a StreamDetector backed by Kick's public channel API,
+// which exposes livestream state without needing any app credentials.
+type KickDetector struct {
+	channelSlug   string
+	checkInterval time.Duration
+
+	httpClient *http.Client
+}
+
+// NewKickDetector creates a KickDetector for the given channel slug (the
+// part of a kick.com/<slug> URL), polling every checkInterval.
+func NewKickDetector(channelSlug string, checkInterval time.Duration) *KickDetector {
+	return &KickDetector{
+		channelSlug:   channelSlug,
+		checkInterval: checkInterval,
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Start polls the Kick channel API for the configured channel's live status.
+func (d *KickDetector) Start(ctx context.Context) <-chan StreamEvent {
+	eventCh := make(chan StreamEvent)
+
+	go func() {
+		ticker := time.NewTicker(d.checkInterval)
+		defer ticker.Stop()
+
+		checkAndUpdateStatus := func() {
+			apiCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+
+			event, err := d.checkStream(apiCtx)
+			if err != nil {
+				log.Printf("Error checking Kick stream status: %v", err)
+				return
+			}
+
+			select {
+			case eventCh <- event:
+			case <-ctx.Done():
+				return
+			case <-time.After(10 * time.Second):
+				log.Println("timed out to send Kick stream status to channel")
+			}
+		}
+
+		checkAndUpdateStatus()
+
+		for {
+			select {
+			case <-ticker.C:
+				checkAndUpdateStatus()
+			case <-ctx.Done():
+				close(eventCh)
+				return
+			}
+		}
+	}()
+
+	return eventCh
+}
+
+func (d *KickDetector) checkStream(ctx context.Context) (StreamEvent, error) {
+	requestURL := fmt.Sprintf(kickChannelURLFormat, url.PathEscape(d.channelSlug))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return StreamEvent{}, fmt.Errorf("failed to build Kick channel request: %w", err)
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return StreamEvent{}, fmt.Errorf("failed to call Kick channel endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return StreamEvent{}, fmt.Errorf("Kick channel endpoint returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Livestream *struct {
+			SessionTitle string `json:"session_title"`
+			ViewerCount  int    `json:"viewer_count"`
+		} `json:"livestream"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return StreamEvent{}, fmt.Errorf("failed to decode Kick channel response: %w", err)
+	}
+
+	event := StreamEvent{Platform: PlatformKick, IsLive: body.Livestream != nil}
+	if event.IsLive {
+		event.Title = body.Livestream.SessionTitle
+		event.ViewerCount = body.Livestream.ViewerCount
+		log.Printf("Kick stream is live: %s", event.Title)
+	} else {
+		log.Println("No active Kick stream found")
+	}
+
+	return event, nil
+}
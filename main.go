@@ -2,17 +2,66 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
 	"time"
-
-	"github.com/lxn/win"
 )
 
 func main() {
+	monitorsFlag := flag.String("monitors", "primary", "which monitors to draw the border on: all, primary, or a comma-separated list of 1-based monitor indices (e.g. 1,3); only honored on Windows")
+	configFlag := flag.String("config", "", "path to config.toml; defaults to ~/.liveframe/config.toml")
+	borderColorFlag := flag.String("border-color", "", "override border.color from config (hex, e.g. #ff0000)")
+	borderWidthFlag := flag.Int("border-width", 0, "override border.width_px from config")
+	borderStyleFlag := flag.String("border-style", "", "override border.style from config: solid, dashed, or pulsing")
+	pollIntervalFlag := flag.Duration("poll-interval", 0, "override poll.interval from config (e.g. 10s)")
+	printConfigFlag := flag.Bool("print-config", false, "print the effective config (defaults plus config.toml plus flag overrides) as TOML and exit")
+	flag.Parse()
+
+	monitors, err := ParseMonitorSelector(*monitorsFlag)
+	if err != nil {
+		log.Fatalf("Invalid --monitors flag: %v", err)
+	}
+
+	configPath := *configFlag
+	if configPath == "" {
+		configPath, err = ConfigFile()
+		if err != nil {
+			log.Fatalf("Failed to get config file path: %v", err)
+		}
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+
+	if *borderColorFlag != "" {
+		cfg.Border.Color = *borderColorFlag
+	}
+	if *borderWidthFlag != 0 {
+		cfg.Border.WidthPx = *borderWidthFlag
+	}
+	if *borderStyleFlag != "" {
+		style, err := ParseBorderStyle(*borderStyleFlag)
+		if err != nil {
+			log.Fatalf("Invalid --border-style flag: %v", err)
+		}
+		cfg.Border.Style = style
+	}
+	if *pollIntervalFlag != 0 {
+		cfg.Poll.Interval = Duration(*pollIntervalFlag)
+	}
+
+	if *printConfigFlag {
+		fmt.Print(EncodeConfigTOML(cfg))
+		return
+	}
+
 	log.Println("Starting LiveFrame - YouTube Streaming Border")
 
 	// Create root context
@@ -81,21 +130,50 @@ func main() {
 	log.Println("OAuth authentication successful")
 
 	// Create border window
-	_, windowManager, err := CreateBorderWindow(ctx)
+	windowManager, err := CreateBorderWindow(ctx, monitors, cfg.Border)
 	if err != nil {
 		log.Fatalf("Error creating window: %v", err)
 	}
 
-	// Set up streaming status check
-	log.Println("Setting up YouTube streaming status check")
-	statusCh := IsLiveStreaming(ctx, client, 5*time.Second)
+	// Set up streaming status checks. OBS WebSocket is preferred when
+	// configured, since it reports state changes instantly and consumes no
+	// YouTube API quota; it falls back to YouTube polling whenever it can't
+	// reach OBS, and drops the fallback again once OBS reconnects. Twitch,
+	// Kick, and RTMP are added alongside whichever of those two is active
+	// when config.toml enables them.
+	var detectors []StreamDetector
+	obsConfigPath := filepath.Join(home, ".liveframe", "obs.json")
+	if obsConfig, err := LoadOBSConfig(obsConfigPath); err == nil {
+		log.Println("Found OBS WebSocket config, using OBS as the live-status source with YouTube fallback")
+		quota := NewQuotaTracker(cfg.Poll.DailyQuotaBudget)
+		youtube := NewYouTubeDetector(client, cfg.Poll.Interval.Duration(), cfg.Poll.MaxInterval.Duration(), cfg.Poll.BackoffOnError, quota)
+		detectors = append(detectors, NewOBSDetectorWithFallback(obsConfig, youtube))
+	} else {
+		log.Printf("No OBS WebSocket config found (%v), using YouTube polling", err)
+		quota := NewQuotaTracker(cfg.Poll.DailyQuotaBudget)
+		detectors = append(detectors, NewYouTubeDetector(client, cfg.Poll.Interval.Duration(), cfg.Poll.MaxInterval.Duration(), cfg.Poll.BackoffOnError, quota))
+	}
+	if twitchConfig, ok := cfg.DetectorConfigFor("twitch"); ok {
+		log.Println("Twitch detector enabled in config")
+		detectors = append(detectors, NewTwitchDetector(twitchConfig.ClientID, twitchConfig.ClientSecret, twitchConfig.UserLogin, cfg.Poll.Interval.Duration()))
+	}
+	if kickConfig, ok := cfg.DetectorConfigFor("kick"); ok {
+		log.Println("Kick detector enabled in config")
+		detectors = append(detectors, NewKickDetector(kickConfig.ChannelSlug, cfg.Poll.Interval.Duration()))
+	}
+	if rtmpConfig, ok := cfg.DetectorConfigFor("rtmp"); ok {
+		log.Println("RTMP detector enabled in config")
+		detectors = append(detectors, NewRTMPDetector(rtmpConfig.URL, cfg.Poll.Interval.Duration()))
+	}
+	events := fanInDetectors(ctx, detectors)
 
 	// Handle streaming status updates with recovery mechanism
 	go func() {
+		liveState := NewLiveState()
 		for {
 			log.Println("Receiving status")
 			select {
-			case isLive, ok := <-statusCh:
+			case event, ok := <-events:
 				log.Println("Received status")
 				if !ok {
 					log.Println("Status channel closed, exiting status handler")
@@ -104,8 +182,17 @@ func main() {
 				}
 
 				// Log status change
-				log.Printf("Received streaming status update: isLive=%v", isLive)
-				windowManager.SetVisible(isLive)
+				log.Printf("Received streaming status update: platform=%s isLive=%v", event.Platform, event.IsLive)
+
+				// Recompute overall visibility from every platform's
+				// last-known state, not just this event's flag, so one
+				// platform going offline doesn't hide the border while
+				// another is still live.
+				visible, livePlatform := liveState.Update(event)
+				if visible {
+					windowManager.SetColor(cfg.ColorForDetector(string(livePlatform), livePlatform))
+				}
+				windowManager.SetVisible(visible)
 
 			case <-ctx.Done():
 				log.Println("Context done, exiting status handler")
@@ -122,34 +209,7 @@ func main() {
 		}
 	}()
 
-	// Message loop - runs until WM_QUIT is received
-	var msg win.MSG
-
-	// Main event loop
-	for {
-		// Check if context is done or process Windows messages
-		select {
-		case <-ctx.Done():
-			log.Println("Context canceled, exiting...")
-			win.PostQuitMessage(0)
-			return
-
-		default:
-			// Process Windows messages using PeekMessage
-			if win.PeekMessage(&msg, 0, 0, 0, win.PM_REMOVE) {
-				if msg.Message == win.WM_QUIT {
-					log.Println("Received WM_QUIT, exiting...")
-					return
-				}
-
-				// Handle Windows messages
-				win.TranslateMessage(&msg)
-				win.DispatchMessage(&msg)
-			} else {
-				// Small sleep to prevent CPU from maxing out
-				// Use a shorter sleep time for better responsiveness
-				time.Sleep(5 * time.Millisecond)
-			}
-		}
-	}
+	// Run the platform overlay event loop until the context is canceled
+	windowManager.Run(ctx)
+	log.Println("Context canceled, exiting...")
 }
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MonitorSelector describes which monitors the border overlay should cover,
+// as parsed from the --monitors CLI flag. Only the Windows backend supports
+// more than one monitor today; other platforms draw on the primary display
+// regardless of this value.
+type MonitorSelector struct {
+	All     bool
+	Primary bool
+	Indices map[int]bool // 1-based indices, as reported by EnumDisplays
+}
+
+// ParseMonitorSelector parses a --monitors flag value: "all", "primary", or
+// a comma-separated list of 1-based monitor indices such as "1,3".
+func ParseMonitorSelector(s string) (MonitorSelector, error) {
+	switch s {
+	case "", "primary":
+		return MonitorSelector{Primary: true}, nil
+	case "all":
+		return MonitorSelector{All: true}, nil
+	}
+
+	indices := make(map[int]bool)
+	for _, part := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return MonitorSelector{}, fmt.Errorf("invalid --monitors value %q: %q is not a monitor index", s, part)
+		}
+		indices[n] = true
+	}
+	return MonitorSelector{Indices: indices}, nil
+}
+
+// Includes reports whether the monitor at the given 1-based index (and
+// primary-ness) is selected.
+func (s MonitorSelector) Includes(index int, isPrimary bool) bool {
+	switch {
+	case s.All:
+		return true
+	case s.Primary:
+		return isPrimary
+	default:
+		return s.Indices[index]
+	}
+}
@@ -0,0 +1,40 @@
+//go:build windows
+
+package main
+
+import (
+	"unsafe"
+
+	"github.com/lxn/win"
+)
+
+// Display describes one active monitor, as reported by EnumDisplayMonitors.
+type Display struct {
+	Index   int // 1-based, in EnumDisplayMonitors order
+	Handle  win.HMONITOR
+	Bounds  win.RECT
+	Primary bool
+}
+
+// EnumDisplays returns every active monitor, in EnumDisplayMonitors order.
+func EnumDisplays() ([]Display, error) {
+	var displays []Display
+
+	EnumDisplayMonitors(func(hMonitor win.HMONITOR, rcMonitor win.RECT) bool {
+		info := win.MONITORINFO{CbSize: uint32(unsafe.Sizeof(win.MONITORINFO{}))}
+		if !win.GetMonitorInfo(hMonitor, &info) {
+			// Skip a monitor we can't query rather than aborting the whole scan.
+			return true
+		}
+
+		displays = append(displays, Display{
+			Index:   len(displays) + 1,
+			Handle:  hMonitor,
+			Bounds:  rcMonitor,
+			Primary: info.DwFlags&win.MONITORINFOF_PRIMARY != 0,
+		})
+		return true
+	})
+
+	return displays, nil
+}
@@ -2,9 +2,13 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -29,9 +33,8 @@ func LoadClientSecretFromFile(filePath string) (*oauth2.Config, error) {
 		return nil, fmt.Errorf("error parsing client secret file: %v", err)
 	}
 
-	// Set the redirect URL
-	config.RedirectURL = "http://localhost:8080/oauth2callback"
-
+	// RedirectURL is rewritten per-flow in GetOAuthClient once a free
+	// loopback port has been chosen.
 	return config, nil
 }
 
@@ -79,6 +82,23 @@ func SaveToken(path string, token *oauth2.Token) error {
 	return nil
 }
 
+// randomURLSafeString returns a cryptographically random string of n bytes
+// of entropy, base64url-encoded without padding (so it's safe to use
+// unescaped in a URL query).
+func randomURLSafeString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// pkceChallenge derives the S256 code_challenge for a given code_verifier.
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
 // GetOAuthClient creates an HTTP server for OAuth flow and returns authorized client
 // If forceAuth is true, it will start a new OAuth flow regardless of existing token
 func GetOAuthClient(ctx context.Context, config *oauth2.Config, forceAuth bool) (*http.Client, error) {
@@ -99,6 +119,27 @@ func GetOAuthClient(ctx context.Context, config *oauth2.Config, forceAuth bool)
 
 	// If forceAuth is true or token doesn't exist/is invalid - start OAuth flow
 	log.Println("Starting new OAuth authentication flow")
+
+	// Bind an ephemeral loopback port ourselves so the redirect URL can be
+	// built before we hand it to net/http.Server, and so we never collide
+	// with a fixed port already in use by something else.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind loopback OAuth callback port: %w", err)
+	}
+	config.RedirectURL = fmt.Sprintf("http://127.0.0.1:%d/oauth2callback", listener.Addr().(*net.TCPAddr).Port)
+
+	// PKCE protects the authorization code from interception; state
+	// protects the callback from CSRF.
+	verifier, err := randomURLSafeString(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PKCE code verifier: %w", err)
+	}
+	state, err := randomURLSafeString(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OAuth state: %w", err)
+	}
+
 	var tok *oauth2.Token
 	codeChan := make(chan string)
 	var wg sync.WaitGroup
@@ -107,20 +148,23 @@ func GetOAuthClient(ctx context.Context, config *oauth2.Config, forceAuth bool)
 	// Create a server mux for the HTTP server
 	mux := http.NewServeMux()
 
-	// Create an HTTP server to handle the OAuth callback
-	server := &http.Server{
-		Addr:    ":8080",
-		Handler: mux,
-	}
+	server := &http.Server{Handler: mux}
 
 	// Set up the handler for the OAuth callback
 	mux.HandleFunc("/oauth2callback", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("state"); got != state {
+			log.Printf("Rejecting OAuth callback with unexpected state %q", got)
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+
 		code := r.URL.Query().Get("code")
 		codeChan <- code
 
 		// Display success message
 		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, "<h1>Authorization Successful</h1><p>You can close this window now.</p>")
+		fmt.Fprint(w, "<!DOCTYPE html><html><head><title>LiveFrame</title></head>"+
+			"<body><h1>Authorization Successful</h1><p>You can close this window now.</p></body></html>")
 
 		// Shutdown the server after a short delay
 		go func() {
@@ -132,7 +176,7 @@ func GetOAuthClient(ctx context.Context, config *oauth2.Config, forceAuth bool)
 
 	// Start the HTTP server
 	go func() {
-		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		if err := server.Serve(listener); err != http.ErrServerClosed {
 			log.Printf("HTTP server error: %v", err)
 		}
 	}()
@@ -141,9 +185,11 @@ func GetOAuthClient(ctx context.Context, config *oauth2.Config, forceAuth bool)
 	// AccessTypeOffline provides a refresh token
 	// ApprovalForce ensures we get a fresh refresh token by forcing the consent screen
 	authURL := config.AuthCodeURL(
-		"state-token",
+		state,
 		oauth2.AccessTypeOffline,
 		oauth2.ApprovalForce,
+		oauth2.SetAuthURLParam("code_challenge", pkceChallenge(verifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
 	)
 
 	// Open the URL in browser
@@ -159,7 +205,7 @@ func GetOAuthClient(ctx context.Context, config *oauth2.Config, forceAuth bool)
 	code := <-codeChan
 
 	// Exchange the code for a token
-	tok, err = config.Exchange(ctx, code)
+	tok, err = config.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", verifier))
 	if err != nil {
 		return nil, fmt.Errorf("error exchanging code for token: %v", err)
 	}
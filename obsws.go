@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// obsEventOutputs is the obs-websocket v5 EventSubscription bit for output
+// events (StreamStateChanged, RecordStateChanged, ...). We only need this
+// one category, so we don't subscribe to the rest.
+const obsEventOutputs = 1 << 6
+
+// obs-websocket v5 message operation codes we use.
+const (
+	obsOpHello      = 0
+	obsOpIdentify   = 1
+	obsOpIdentified = 2
+	obsOpEvent      = 5
+)
+
+// OBSConfig holds the connection details for an OBS Studio instance running
+// the obs-websocket v5 plugin, loaded from ~/.liveframe/obs.json.
+type OBSConfig struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Password string `json:"password"`
+}
+
+// LoadOBSConfig loads the OBS WebSocket config from the specified file. A
+// missing file is the expected way to opt out of OBS integration, so callers
+// should treat a non-nil error as "not configured" rather than fatal.
+func LoadOBSConfig(filePath string) (*OBSConfig, error) {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading OBS config file: %w", err)
+	}
+
+	var cfg OBSConfig
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("error parsing OBS config file: %w", err)
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 4455 // obs-websocket's default port
+	}
+
+	return &cfg, nil
+}
+
+// obsMessage is the envelope every obs-websocket v5 message is wrapped in.
+type obsMessage struct {
+	Op int             `json:"op"`
+	D  json.RawMessage `json:"d"`
+}
+
+// OBSDetector is a StreamDetector backed by OBS Studio's obs-websocket v5
+// plugin. Unlike the polling detectors, it receives StreamStateChanged
+// events the instant OBS starts or stops streaming, at no API quota cost.
+type OBSDetector struct {
+	cfg *OBSConfig
+
+	// onConnected, if set, is called after every successful Hello/Identify
+	// handshake. OBSDetectorWithFallback uses it to know when to stop its
+	// fallback detector.
+	onConnected func()
+}
+
+// NewOBSDetector creates an OBSDetector for the given OBS WebSocket config.
+func NewOBSDetector(cfg *OBSConfig) *OBSDetector {
+	return &OBSDetector{cfg: cfg}
+}
+
+// obsFallbackThreshold is the number of consecutive OBS WebSocket connection
+// failures OBSDetectorWithFallback tolerates before it starts its fallback
+// detector alongside OBS.
+const obsFallbackThreshold = 3
+
+// OBSDetectorWithFallback runs an OBSDetector as the primary live-status
+// source, and starts fallback alongside it once OBS has failed to connect
+// obsFallbackThreshold times in a row. It stops fallback again as soon as
+// OBS reconnects, so a polling detector like YouTube's doesn't keep
+// spending API quota once OBS resumes reporting state changes for free.
+type OBSDetectorWithFallback struct {
+	obs      *OBSDetector
+	fallback StreamDetector
+}
+
+// NewOBSDetectorWithFallback creates an OBSDetectorWithFallback for the given
+// OBS WebSocket config, falling back to fallback while OBS is unreachable.
+func NewOBSDetectorWithFallback(cfg *OBSConfig, fallback StreamDetector) *OBSDetectorWithFallback {
+	return &OBSDetectorWithFallback{obs: NewOBSDetector(cfg), fallback: fallback}
+}
+
+// Start connects to OBS and streams its events until ctx is canceled,
+// starting fallback's own events alongside them once OBS has been
+// unreachable for obsFallbackThreshold consecutive attempts.
+func (d *OBSDetectorWithFallback) Start(ctx context.Context) <-chan StreamEvent {
+	out := make(chan StreamEvent)
+
+	go func() {
+		defer close(out)
+
+		var failures int
+		var stopFallback context.CancelFunc
+		defer func() {
+			if stopFallback != nil {
+				stopFallback()
+			}
+		}()
+
+		d.obs.onConnected = func() {
+			failures = 0
+			if stopFallback != nil {
+				stopFallback()
+				stopFallback = nil
+			}
+		}
+
+		for {
+			if err := d.obs.connectAndServe(ctx, out); err != nil {
+				log.Printf("OBS WebSocket connection error: %v", err)
+
+				failures++
+				if failures == obsFallbackThreshold && stopFallback == nil {
+					log.Printf("OBS WebSocket unreachable after %d attempts, falling back to YouTube polling until it reconnects", failures)
+					fallbackCtx, cancel := context.WithCancel(ctx)
+					stopFallback = cancel
+					go forwardEvents(fallbackCtx, d.fallback, out)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}()
+
+	return out
+}
+
+// Start connects to OBS and streams StreamStateChanged events until ctx is
+// canceled, reconnecting with a fixed backoff if the connection drops.
+func (d *OBSDetector) Start(ctx context.Context) <-chan StreamEvent {
+	eventCh := make(chan StreamEvent)
+
+	go func() {
+		defer close(eventCh)
+
+		for {
+			if err := d.connectAndServe(ctx, eventCh); err != nil {
+				log.Printf("OBS WebSocket connection error: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}()
+
+	return eventCh
+}
+
+// connectAndServe performs the Hello/Identify handshake and then forwards
+// StreamStateChanged events to eventCh until the connection closes or ctx is
+// canceled.
+func (d *OBSDetector) connectAndServe(ctx context.Context, eventCh chan<- StreamEvent) error {
+	url := fmt.Sprintf("ws://%s:%d", d.cfg.Host, d.cfg.Port)
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to obs-websocket at %s: %w", url, err)
+	}
+	defer conn.Close()
+
+	// done is closed when connectAndServe returns, so this watcher doesn't
+	// outlive this one connection attempt; OBSDetector.Start retries every
+	// 5s, and without this each retry would leak another goroutine parked
+	// on the outer, whole-program ctx until the process exits.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	if err := d.identify(conn); err != nil {
+		return err
+	}
+	log.Println("Connected to OBS WebSocket, watching for stream state changes")
+	if d.onConnected != nil {
+		d.onConnected()
+	}
+
+	for {
+		var msg obsMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return fmt.Errorf("OBS WebSocket connection closed: %w", err)
+		}
+		if msg.Op != obsOpEvent {
+			continue
+		}
+
+		var event struct {
+			EventType string `json:"eventType"`
+			EventData struct {
+				OutputActive bool `json:"outputActive"`
+			} `json:"eventData"`
+		}
+		if err := json.Unmarshal(msg.D, &event); err != nil {
+			log.Printf("Failed to decode OBS event: %v", err)
+			continue
+		}
+		if event.EventType != "StreamStateChanged" {
+			continue
+		}
+
+		log.Printf("OBS stream state changed: active=%v", event.EventData.OutputActive)
+
+		select {
+		case eventCh <- StreamEvent{Platform: PlatformOBS, IsLive: event.EventData.OutputActive}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// identify performs the Hello/Identify handshake, authenticating with the
+// configured password if OBS requests it.
+func (d *OBSDetector) identify(conn *websocket.Conn) error {
+	var hello obsMessage
+	if err := conn.ReadJSON(&hello); err != nil {
+		return fmt.Errorf("failed to read Hello message: %w", err)
+	}
+	if hello.Op != obsOpHello {
+		return fmt.Errorf("expected Hello (op %d), got op %d", obsOpHello, hello.Op)
+	}
+
+	var helloData struct {
+		RPCVersion     int `json:"rpcVersion"`
+		Authentication *struct {
+			Challenge string `json:"challenge"`
+			Salt      string `json:"salt"`
+		} `json:"authentication"`
+	}
+	if err := json.Unmarshal(hello.D, &helloData); err != nil {
+		return fmt.Errorf("failed to decode Hello message: %w", err)
+	}
+
+	identify := struct {
+		RPCVersion         int    `json:"rpcVersion"`
+		Authentication     string `json:"authentication,omitempty"`
+		EventSubscriptions int    `json:"eventSubscriptions"`
+	}{
+		RPCVersion:         helloData.RPCVersion,
+		EventSubscriptions: obsEventOutputs,
+	}
+	if helloData.Authentication != nil {
+		identify.Authentication = obsAuthString(d.cfg.Password, helloData.Authentication.Salt, helloData.Authentication.Challenge)
+	}
+
+	identifyData, err := json.Marshal(identify)
+	if err != nil {
+		return fmt.Errorf("failed to encode Identify message: %w", err)
+	}
+	if err := conn.WriteJSON(obsMessage{Op: obsOpIdentify, D: identifyData}); err != nil {
+		return fmt.Errorf("failed to send Identify message: %w", err)
+	}
+
+	var identified obsMessage
+	if err := conn.ReadJSON(&identified); err != nil {
+		return fmt.Errorf("failed to read Identified message: %w", err)
+	}
+	if identified.Op != obsOpIdentified {
+		return fmt.Errorf("expected Identified (op %d), got op %d", obsOpIdentified, identified.Op)
+	}
+
+	return nil
+}
+
+// obsAuthString computes the obs-websocket v5 authentication string:
+// base64(sha256(base64(sha256(password+salt)) + challenge)).
+func obsAuthString(password, salt, challenge string) string {
+	secretHash := sha256.Sum256([]byte(password + salt))
+	secret := base64.StdEncoding.EncodeToString(secretHash[:])
+
+	authHash := sha256.Sum256([]byte(secret + challenge))
+	return base64.StdEncoding.EncodeToString(authHash[:])
+}
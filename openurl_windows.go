@@ -0,0 +1,44 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ShellExecute wraps the Windows API function to open URLs
+var shell32 = windows.NewLazyDLL("shell32.dll")
+var procShellExecute = shell32.NewProc("ShellExecuteW")
+
+// OpenURL opens a URL in the default browser
+func OpenURL(url string) error {
+	verb := "open"
+	lpFile := url
+
+	verbPtr, err := windows.UTF16PtrFromString(verb)
+	if err != nil {
+		return fmt.Errorf("failed to convert verb to UTF16: %w", err)
+	}
+
+	lpFilePtr, err := windows.UTF16PtrFromString(lpFile)
+	if err != nil {
+		return fmt.Errorf("failed to convert URL to UTF16: %w", err)
+	}
+
+	ret, _, _ := procShellExecute.Call(
+		uintptr(0),
+		uintptr(unsafe.Pointer(verbPtr)),
+		uintptr(unsafe.Pointer(lpFilePtr)),
+		uintptr(0),
+		uintptr(0),
+		uintptr(1), // SW_SHOWNORMAL
+	)
+
+	if ret <= 32 {
+		return fmt.Errorf("failed to open URL: %w", windows.GetLastError())
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WindowManager owns the on-screen streaming border: it tracks visibility and
+// runs whatever platform-specific event loop is needed to keep the border
+// window painted.
+//
+// Each supported platform provides CreateBorderWindow and a WindowManager
+// implementation in a build-tagged overlay_<platform>.go file, so no
+// platform-specific package (e.g. github.com/lxn/win) is imported outside of
+// those files.
+type WindowManager interface {
+	// SetVisible shows or hides the border.
+	SetVisible(visible bool)
+
+	// SetColor sets the border color, taking effect on the next paint.
+	SetColor(r, g, b byte)
+
+	// Run processes platform events until ctx is canceled. It returns once
+	// the overlay has been torn down.
+	Run(ctx context.Context)
+}
+
+// wrapBorderStyle applies any animation implied by style on top of wm.
+// Backends only need to implement solid coloring via SetColor/SetVisible;
+// pulsing is layered here so it works identically on every platform without
+// touching overlay_<platform>.go. Dashed borders need per-backend geometry
+// changes that aren't implemented yet, so they currently render solid.
+func wrapBorderStyle(wm WindowManager, style BorderStyle) WindowManager {
+	if style == BorderStylePulsing {
+		return newPulsingWindowManager(wm)
+	}
+	return wm
+}
+
+// pulsingInterval is how often a pulsing border toggles between full and
+// half brightness.
+const pulsingInterval = 500 * time.Millisecond
+
+// pulsingWindowManager wraps another WindowManager and animates its color's
+// brightness while visible.
+type pulsingWindowManager struct {
+	inner WindowManager
+
+	mu      sync.Mutex
+	r, g, b byte
+	cancel  context.CancelFunc
+}
+
+func newPulsingWindowManager(inner WindowManager) *pulsingWindowManager {
+	return &pulsingWindowManager{inner: inner}
+}
+
+// SetColor records the color to pulse between full and half brightness, and
+// applies it immediately.
+func (wm *pulsingWindowManager) SetColor(r, g, b byte) {
+	wm.mu.Lock()
+	wm.r, wm.g, wm.b = r, g, b
+	wm.mu.Unlock()
+
+	wm.inner.SetColor(r, g, b)
+}
+
+// SetVisible shows or hides the border, starting or stopping the pulse
+// animation accordingly.
+func (wm *pulsingWindowManager) SetVisible(visible bool) {
+	wm.mu.Lock()
+	if wm.cancel != nil {
+		wm.cancel()
+		wm.cancel = nil
+	}
+	wm.mu.Unlock()
+
+	wm.inner.SetVisible(visible)
+
+	if visible {
+		ctx, cancel := context.WithCancel(context.Background())
+		wm.mu.Lock()
+		wm.cancel = cancel
+		wm.mu.Unlock()
+		go wm.pulse(ctx)
+	}
+}
+
+func (wm *pulsingWindowManager) pulse(ctx context.Context) {
+	ticker := time.NewTicker(pulsingInterval)
+	defer ticker.Stop()
+
+	dim := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			wm.mu.Lock()
+			r, g, b := wm.r, wm.g, wm.b
+			wm.mu.Unlock()
+
+			if dim {
+				r, g, b = r/2, g/2, b/2
+			}
+			dim = !dim
+			wm.inner.SetColor(r, g, b)
+		}
+	}
+}
+
+// Run delegates to the wrapped WindowManager's event loop.
+func (wm *pulsingWindowManager) Run(ctx context.Context) {
+	wm.inner.Run(ctx)
+}
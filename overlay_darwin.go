@@ -0,0 +1,119 @@
+//go:build darwin
+
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+
+#import <Cocoa/Cocoa.h>
+
+// createBorderWindow creates a borderless, click-through, always-on-top
+// NSWindow spanning the main screen with a red border drawn around its
+// edge, and returns it (initially hidden) for later show/hide from Go.
+// Implementation lives in overlay_darwin.m. showBorderWindow and
+// setBorderColor are safe to call from any goroutine: they hop onto the
+// main thread internally via dispatch_async before touching the window.
+void *createBorderWindow(double borderWidth);
+void showBorderWindow(void *window, int visible);
+void setBorderColor(void *window, double r, double g, double b);
+void runMainLoopOnce(void);
+*/
+import "C"
+
+import (
+	"context"
+	"log"
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// borderWidth is the border thickness in pixels, overridden from
+// border.width_px in CreateBorderWindow.
+var borderWidth = 2
+
+func init() {
+	// All AppKit calls (NSWindow creation, NSApp's event pump) must happen
+	// on the process's main OS thread; pin goroutine 1 to it before any
+	// Cocoa call is made.
+	runtime.LockOSThread()
+}
+
+// darwinWindowManager is the Cocoa WindowManager backend.
+type darwinWindowManager struct {
+	window unsafe.Pointer
+	mu     sync.Mutex
+}
+
+// SetVisible shows or hides the border. Safe to call from any goroutine;
+// the actual AppKit call is dispatched onto the main thread in C.
+func (wm *darwinWindowManager) SetVisible(visible bool) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	shown := C.int(0)
+	if visible {
+		shown = C.int(1)
+	}
+	C.showBorderWindow(wm.window, shown)
+
+	if visible {
+		log.Println("Window is now visible - YouTube stream detected")
+	} else {
+		log.Println("Window is now hidden - No active YouTube stream")
+	}
+}
+
+// SetColor sets the border color, taking effect on the next paint. Safe to
+// call from any goroutine; the actual AppKit call is dispatched onto the
+// main thread in C.
+func (wm *darwinWindowManager) SetColor(r, g, b byte) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	C.setBorderColor(wm.window, C.double(r)/255, C.double(g)/255, C.double(b)/255)
+}
+
+// Run pumps the Cocoa run loop until ctx is canceled.
+func (wm *darwinWindowManager) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Context canceled, exiting...")
+			return
+		default:
+			C.runMainLoopOnce()
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+}
+
+// CreateBorderWindow creates the border window that will be shown during
+// streaming. selector is accepted for interface parity with the Windows
+// backend, which is the only one that currently supports more than one
+// monitor; this backend always covers NSScreen.mainScreen.
+func CreateBorderWindow(ctx context.Context, selector MonitorSelector, border BorderConfig) (WindowManager, error) {
+	if border.WidthPx > 0 {
+		borderWidth = border.WidthPx
+	}
+
+	window := C.createBorderWindow(C.double(borderWidth))
+
+	windowManager := &darwinWindowManager{window: window}
+	if r, g, b, err := border.RGB(); err == nil {
+		windowManager.SetColor(r, g, b)
+	} else {
+		log.Printf("Warning: invalid border color %q, using default: %v", border.Color, err)
+	}
+	windowManager.SetVisible(false)
+
+	go func() {
+		<-ctx.Done()
+		log.Printf("destroying window due to context cancel")
+		C.showBorderWindow(window, 0)
+	}()
+
+	return wrapBorderStyle(windowManager, border.Style), nil
+}
@@ -0,0 +1,248 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
+
+// waylandGlobals holds the registry globals we need out of the initial
+// Wayland registry roundtrip.
+type waylandGlobals struct {
+	compositor *client.Compositor
+	shm        *client.Shm
+	layerShell *WlrLayerShell
+}
+
+// bindWaylandGlobals binds the wl_compositor, wl_shm and
+// zwlr_layer_shell_v1 globals advertised by the compositor and blocks for
+// one roundtrip so they're ready to use. layerShell is left nil if the
+// compositor doesn't support the layer-shell protocol, which the caller
+// treats as "fall back to X11".
+func bindWaylandGlobals(display *client.Display, registry *client.Registry) (*waylandGlobals, error) {
+	globals := &waylandGlobals{}
+
+	registry.SetGlobalHandler(func(e client.RegistryGlobalEvent) {
+		switch e.Interface {
+		case "wl_compositor":
+			globals.compositor = client.NewCompositor(display.Context())
+			registry.Bind(e.Name, e.Interface, e.Version, globals.compositor)
+		case "wl_shm":
+			globals.shm = client.NewShm(display.Context())
+			registry.Bind(e.Name, e.Interface, e.Version, globals.shm)
+		case "zwlr_layer_shell_v1":
+			globals.layerShell = NewWlrLayerShell(display.Context())
+			registry.Bind(e.Name, e.Interface, e.Version, globals.layerShell)
+		}
+	})
+
+	callback, err := display.Sync()
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync Wayland display: %w", err)
+	}
+	defer callback.Destroy()
+
+	done := make(chan struct{})
+	callback.SetDoneHandler(func(client.CallbackDoneEvent) { close(done) })
+
+	for {
+		if err := display.Context().Dispatch(); err != nil {
+			return nil, fmt.Errorf("failed to dispatch Wayland events: %w", err)
+		}
+		select {
+		case <-done:
+			if globals.compositor == nil || globals.shm == nil {
+				return nil, fmt.Errorf("compositor did not advertise wl_compositor/wl_shm")
+			}
+			return globals, nil
+		default:
+		}
+	}
+}
+
+// waylandBackend draws the border as a wlr-layer-shell overlay surface, so it
+// works under any wlroots-based compositor (Sway, Hyprland, etc.) without
+// needing X11/XWayland at all.
+type waylandBackend struct {
+	display *client.Display
+	shm     *client.Shm
+	surface *client.Surface
+	layer   *WlrLayerSurface
+
+	mu            sync.Mutex
+	width, height uint32 // learned from the compositor's configure event
+	pendingShow   bool   // show() was called before the first configure arrived
+	color         uint32 // 0xRRGGBB
+}
+
+func newWaylandBackend() (*waylandBackend, error) {
+	display, err := client.Connect("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Wayland display: %w", err)
+	}
+
+	registry, err := display.GetRegistry()
+	if err != nil {
+		display.Context().Close()
+		return nil, fmt.Errorf("failed to get Wayland registry: %w", err)
+	}
+
+	globals, err := bindWaylandGlobals(display, registry)
+	if err != nil {
+		display.Context().Close()
+		return nil, err
+	}
+
+	if globals.layerShell == nil {
+		display.Context().Close()
+		return nil, fmt.Errorf("compositor does not support zwlr_layer_shell_v1")
+	}
+
+	surface, err := globals.compositor.CreateSurface()
+	if err != nil {
+		display.Context().Close()
+		return nil, fmt.Errorf("failed to create Wayland surface: %w", err)
+	}
+
+	layer, err := globals.layerShell.GetLayerSurface(surface, nil, WlrLayerShellLayerOverlay, "liveframe-border")
+	if err != nil {
+		display.Context().Close()
+		return nil, fmt.Errorf("failed to create layer surface: %w", err)
+	}
+
+	// Anchor to all four edges with no explicit size so the compositor
+	// stretches the surface to fill the output and tells us its pixel
+	// size via the configure event.
+	layer.SetAnchor(WlrLayerSurfaceAnchorTop | WlrLayerSurfaceAnchorBottom | WlrLayerSurfaceAnchorLeft | WlrLayerSurfaceAnchorRight)
+	layer.SetExclusiveZone(-1)
+	layer.SetKeyboardInteractivity(WlrLayerSurfaceKeyboardInteractivityNone)
+
+	b := &waylandBackend{display: display, shm: globals.shm, surface: surface, layer: layer, color: 0xFF0000}
+
+	layer.SetConfigureHandler(func(e WlrLayerSurfaceConfigureEvent) {
+		layer.AckConfigure(e.Serial)
+
+		b.mu.Lock()
+		b.width, b.height = e.Width, e.Height
+		show := b.pendingShow
+		b.mu.Unlock()
+
+		if show {
+			if err := b.paint(); err != nil {
+				fmt.Printf("failed to paint border after configure: %v\n", err)
+			}
+		}
+	})
+
+	surface.Commit()
+
+	return b, nil
+}
+
+func (b *waylandBackend) setColor(r, g, bl byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.color = uint32(r)<<16 | uint32(g)<<8 | uint32(bl)
+}
+
+func (b *waylandBackend) show(visible bool) error {
+	b.mu.Lock()
+	b.pendingShow = visible
+	haveSize := b.width != 0 && b.height != 0
+	b.mu.Unlock()
+
+	if !visible {
+		b.surface.Attach(nil, 0, 0)
+		b.surface.Commit()
+		return nil
+	}
+
+	if !haveSize {
+		// Nothing to draw yet; the configure handler will paint once the
+		// compositor tells us the surface size.
+		return nil
+	}
+	return b.paint()
+}
+
+func (b *waylandBackend) paint() error {
+	b.mu.Lock()
+	width, height, color := b.width, b.height, b.color
+	b.mu.Unlock()
+
+	buf, err := renderBorderBuffer(b.shm, width, height, int32(borderWidth), color)
+	if err != nil {
+		return fmt.Errorf("failed to render border buffer: %w", err)
+	}
+
+	b.surface.Attach(buf, 0, 0)
+	b.surface.DamageBuffer(0, 0, int32(width), int32(height))
+	b.surface.Commit()
+	return nil
+}
+
+func (b *waylandBackend) pump() bool {
+	return b.display.Context().Dispatch() == nil
+}
+
+func (b *waylandBackend) close() {
+	b.layer.Destroy()
+	b.surface.Destroy()
+	b.display.Context().Close()
+}
+
+// renderBorderBuffer allocates an anonymous shm-backed ARGB8888 buffer sized
+// width x height and paints a border of the given thickness and color
+// around its edge, leaving the interior fully transparent.
+func renderBorderBuffer(shm *client.Shm, width, height uint32, thickness int32, color uint32) (*client.Buffer, error) {
+	size := int(width) * int(height) * 4
+
+	f, err := os.CreateTemp("", "liveframe-shm-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shm-backed temp file: %w", err)
+	}
+	defer f.Close()
+	os.Remove(f.Name())
+
+	if err := f.Truncate(int64(size)); err != nil {
+		return nil, fmt.Errorf("failed to size shm-backed temp file: %w", err)
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mmap shm-backed temp file: %w", err)
+	}
+	defer syscall.Munmap(data)
+
+	pixels := make([]uint32, int(width)*int(height))
+	stride, w, h, t := int(width), int(width), int(height), int(thickness)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			onBorder := x < t || y < t || x >= w-t || y >= h-t
+			if onBorder {
+				pixels[y*stride+x] = 0xFF000000 | color
+			}
+		}
+	}
+	copy(data, uint32SliceToBytes(pixels))
+
+	pool, err := shm.CreatePool(int(f.Fd()), int32(size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wl_shm_pool: %w", err)
+	}
+	defer pool.Destroy()
+
+	return pool.CreateBuffer(0, int32(width), int32(height), int32(width)*4, uint32(client.ShmFormatArgb8888))
+}
+
+// uint32SliceToBytes reinterprets a []uint32 as a []byte without copying, so
+// it can be written straight into the mmap'd shm region.
+func uint32SliceToBytes(pixels []uint32) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(&pixels[0])), len(pixels)*4)
+}
@@ -0,0 +1,355 @@
+//go:build windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/lxn/win"
+)
+
+// borderWidth is the border thickness in pixels, overridden from
+// border.width_px in CreateBorderWindow.
+var borderWidth = 2
+
+var (
+	className  = mustGetUTF16PtrFromString("RedBorderWindow")
+	windowName = mustGetUTF16PtrFromString("LiveFrame - YouTube Streaming Border")
+
+	idcArrow = mustGetUTF16PtrFromString("IDC_ARROW")
+)
+
+// borderColor holds the current border color. wndProc is a free function
+// (Windows calls it directly), so the color lives here instead of on
+// windowsWindowManager.
+var borderColor = struct {
+	mu    sync.Mutex
+	color win.COLORREF
+}{color: win.RGB(255, 0, 0)}
+
+// activeManager lets wndProc (also a free function) reach back into the
+// windowsWindowManager to react to WM_DISPLAYCHANGE/WM_DPICHANGED.
+var activeManager *windowsWindowManager
+
+func mustGetUTF16PtrFromString(str string) *uint16 {
+	ptr, err := syscall.UTF16PtrFromString(str)
+	if err != nil {
+		panic(fmt.Sprintf("failed to convert string %q to UTF16 pointer", str))
+	}
+
+	return ptr
+}
+
+// windowsWindowManager is the Win32 WindowManager backend. It owns one
+// borderless window per monitor selected by `selector`.
+type windowsWindowManager struct {
+	mu           sync.Mutex
+	selector     MonitorSelector
+	hwnds        []win.HWND
+	visible      bool
+	shuttingDown bool
+}
+
+// isShuttingDown reports whether the manager itself is being torn down (as
+// opposed to recreateWindows destroying the old per-monitor hwnds on a
+// display change), so wndProc knows whether a WM_DESTROY should end the
+// message loop.
+func (wm *windowsWindowManager) isShuttingDown() bool {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	return wm.shuttingDown
+}
+
+// SetVisible sets the window visibility
+func (wm *windowsWindowManager) SetVisible(visible bool) {
+	if wm == nil {
+		log.Println("Warning: windowManager is nil, cannot update visibility")
+		return
+	}
+
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	wm.visible = visible
+	for _, hwnd := range wm.hwnds {
+		if visible {
+			win.ShowWindow(hwnd, win.SW_SHOW)
+			win.UpdateWindow(hwnd)
+		} else {
+			win.ShowWindow(hwnd, win.SW_HIDE)
+		}
+	}
+
+	if visible {
+		log.Println("Window is now visible - YouTube stream detected")
+	} else {
+		log.Println("Window is now hidden - No active YouTube stream")
+	}
+}
+
+// SetColor sets the border color, taking effect on the next paint.
+func (wm *windowsWindowManager) SetColor(r, g, b byte) {
+	borderColor.mu.Lock()
+	borderColor.color = win.RGB(r, g, b)
+	borderColor.mu.Unlock()
+
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	for _, hwnd := range wm.hwnds {
+		win.InvalidateRect(hwnd, nil, true)
+	}
+}
+
+// recreateWindows tears down the existing border windows and creates one
+// per currently-selected monitor. Called on startup and again whenever
+// Windows reports a display configuration change.
+func (wm *windowsWindowManager) recreateWindows() error {
+	displays, err := EnumDisplays()
+	if err != nil {
+		return fmt.Errorf("failed to enumerate displays: %w", err)
+	}
+
+	wm.mu.Lock()
+	oldHwnds := wm.hwnds
+	visible := wm.visible
+	wm.mu.Unlock()
+
+	var newHwnds []win.HWND
+	for _, d := range displays {
+		if !wm.selector.Includes(d.Index, d.Primary) {
+			continue
+		}
+
+		hwnd, err := createBorderWindowForRect(d.Bounds)
+		if err != nil {
+			log.Printf("Warning: failed to create border window for monitor %d: %v", d.Index, err)
+			continue
+		}
+		newHwnds = append(newHwnds, hwnd)
+	}
+
+	wm.mu.Lock()
+	wm.hwnds = newHwnds
+	wm.mu.Unlock()
+
+	for _, hwnd := range oldHwnds {
+		win.DestroyWindow(hwnd)
+	}
+
+	wm.SetVisible(visible)
+	return nil
+}
+
+// handleDisplayChange is invoked from wndProc when Windows posts
+// WM_DISPLAYCHANGE, i.e. a monitor was plugged in, unplugged, or resized.
+func (wm *windowsWindowManager) handleDisplayChange() {
+	log.Println("Display configuration changed, recreating border windows")
+	if err := wm.recreateWindows(); err != nil {
+		log.Printf("Warning: failed to recreate border windows after display change: %v", err)
+	}
+}
+
+// Run pumps the Win32 message loop until ctx is canceled.
+func (wm *windowsWindowManager) Run(ctx context.Context) {
+	var msg win.MSG
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Context canceled, exiting...")
+			win.PostQuitMessage(0)
+			return
+
+		default:
+			// Process Windows messages using PeekMessage
+			if win.PeekMessage(&msg, 0, 0, 0, win.PM_REMOVE) {
+				if msg.Message == win.WM_QUIT {
+					log.Println("Received WM_QUIT, exiting...")
+					return
+				}
+
+				// Handle Windows messages
+				win.TranslateMessage(&msg)
+				win.DispatchMessage(&msg)
+			} else {
+				// Small sleep to prevent CPU from maxing out
+				// Use a shorter sleep time for better responsiveness
+				time.Sleep(5 * time.Millisecond)
+			}
+		}
+	}
+}
+
+// CreateBorderWindow creates the border window(s) that will be shown during
+// streaming, one per monitor selected by `selector`.
+func CreateBorderWindow(ctx context.Context, selector MonitorSelector, border BorderConfig) (WindowManager, error) {
+	if border.WidthPx > 0 {
+		borderWidth = border.WidthPx
+	}
+	if r, g, b, err := border.RGB(); err == nil {
+		borderColor.mu.Lock()
+		borderColor.color = win.RGB(r, g, b)
+		borderColor.mu.Unlock()
+	} else {
+		log.Printf("Warning: invalid border color %q, using default: %v", border.Color, err)
+	}
+
+	// Register window class
+
+	hInstance := win.GetModuleHandle(nil)
+
+	var icex win.INITCOMMONCONTROLSEX
+	icex.DwSize = uint32(unsafe.Sizeof(icex))
+	icex.DwICC = win.ICC_STANDARD_CLASSES
+	win.InitCommonControlsEx(&icex)
+
+	wcex := win.WNDCLASSEX{
+		CbSize:        uint32(unsafe.Sizeof(win.WNDCLASSEX{})),
+		Style:         win.CS_HREDRAW | win.CS_VREDRAW,
+		LpfnWndProc:   syscall.NewCallback(wndProc),
+		HInstance:     hInstance,
+		HCursor:       win.LoadCursor(0, idcArrow),
+		HbrBackground: win.HBRUSH(win.GetStockObject(win.BLACK_BRUSH)),
+		LpszClassName: className,
+	}
+
+	if atom := win.RegisterClassEx(&wcex); atom == 0 {
+		return nil, fmt.Errorf("RegisterClassEx failed")
+	}
+
+	windowManager := &windowsWindowManager{selector: selector}
+	activeManager = windowManager
+
+	if err := windowManager.recreateWindows(); err != nil {
+		return nil, err
+	}
+
+	// Clean up when context is done
+	go func() {
+		<-ctx.Done()
+
+		log.Printf("destroying window due to context cancel")
+		windowManager.mu.Lock()
+		windowManager.shuttingDown = true
+		hwnds := windowManager.hwnds
+		windowManager.mu.Unlock()
+		for _, hwnd := range hwnds {
+			win.DestroyWindow(hwnd)
+		}
+	}()
+
+	return wrapBorderStyle(windowManager, border.Style), nil
+}
+
+// createBorderWindowForRect creates a single borderless, click-through,
+// always-on-top window spanning the given screen rectangle.
+func createBorderWindowForRect(rc win.RECT) (win.HWND, error) {
+	hInstance := win.GetModuleHandle(nil)
+
+	hwnd := win.CreateWindowEx(
+		WS_EX_LAYERED|WS_EX_TOPMOST|WS_EX_NOACTIVATE,
+		className,
+		windowName,
+		win.WS_POPUP,
+		rc.Left, rc.Top, rc.Right-rc.Left, rc.Bottom-rc.Top,
+		0, 0, hInstance, nil,
+	)
+	if hwnd == 0 {
+		return 0, fmt.Errorf("CreateWindowEx failed")
+	}
+
+	// Make window transparent except for the border
+	win.SetWindowLong(hwnd, win.GWL_EXSTYLE, win.GetWindowLong(hwnd, win.GWL_EXSTYLE)|WS_EX_LAYERED)
+
+	// Make window transparent
+	if !SetLayeredWindowAttributes(hwnd, 0, 0, LWA_COLORKEY) {
+		win.DestroyWindow(hwnd)
+		return 0, fmt.Errorf("SetLayeredWindowAttributes failed")
+	}
+
+	return hwnd, nil
+}
+
+func drawBorder(hwnd win.HWND) {
+	var rc win.RECT
+	win.GetClientRect(hwnd, &rc)
+
+	hdc := win.GetDC(hwnd)
+	defer win.ReleaseDC(hwnd, hdc)
+
+	borderColor.mu.Lock()
+	color := borderColor.color
+	borderColor.mu.Unlock()
+
+	// Create a brush in the current border color
+	redBrush := CreateSolidBrush(color)
+	defer win.DeleteObject(win.HGDIOBJ(redBrush))
+
+	// Select the brush into the DC
+	oldBrush := win.SelectObject(hdc, win.HGDIOBJ(redBrush))
+	defer win.SelectObject(hdc, oldBrush)
+
+	// Draw the top border
+	PatBlt(hdc, 0, 0, int(rc.Right), borderWidth, PATCOPY)
+
+	// Draw the bottom border
+	PatBlt(hdc, 0, int(rc.Bottom)-borderWidth, int(rc.Right), borderWidth, PATCOPY)
+
+	// Draw the left border
+	PatBlt(hdc, 0, 0, borderWidth, int(rc.Bottom), PATCOPY)
+
+	// Draw the right border
+	PatBlt(hdc, int(rc.Right)-borderWidth, 0, borderWidth, int(rc.Bottom), PATCOPY)
+}
+
+func wndProc(hwnd win.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+	switch msg {
+	case win.WM_DESTROY:
+		// recreateWindows destroys the old per-monitor hwnds on every
+		// WM_DISPLAYCHANGE/WM_DPICHANGED while the app keeps running, so only
+		// quit the message loop when the manager itself is shutting down;
+		// otherwise Run's loop would exit on a monitor hot-plug or DPI change.
+		if activeManager == nil || activeManager.isShuttingDown() {
+			win.PostQuitMessage(0)
+		}
+		return 0
+
+	case win.WM_KEYDOWN:
+		// Close on ESC key
+		if wParam == win.VK_ESCAPE {
+			win.DestroyWindow(hwnd)
+		}
+		return 0
+
+	case win.WM_PAINT:
+		var ps win.PAINTSTRUCT
+		win.BeginPaint(hwnd, &ps)
+		drawBorder(hwnd)
+		win.EndPaint(hwnd, &ps)
+		return 0
+
+	case win.WM_DISPLAYCHANGE:
+		// A monitor was plugged in, unplugged, or changed resolution.
+		// Recreate the border windows to match the new layout.
+		if activeManager != nil {
+			activeManager.handleDisplayChange()
+		}
+		return 0
+
+	case win.WM_DPICHANGED:
+		// A monitor's DPI scaling changed; re-enumerate and recreate the
+		// border windows so their bounds match the new layout.
+		if activeManager != nil {
+			activeManager.handleDisplayChange()
+		}
+		return 0
+	}
+
+	return win.DefWindowProc(hwnd, msg, wParam, lParam)
+}
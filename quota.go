@@ -0,0 +1,78 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// QuotaTracker estimates API units consumed across a rolling calendar day
+// and refuses further calls once a configured daily budget is hit. It's
+// conservative about the reset boundary: the real YouTube Data API quota
+// resets at midnight Pacific time, but tracking that precisely would need
+// an extra timezone dependency, so QuotaTracker resets at midnight UTC
+// instead.
+type QuotaTracker struct {
+	mu       sync.Mutex
+	budget   int
+	used     int
+	dayStart time.Time
+}
+
+// NewQuotaTracker creates a QuotaTracker with the given daily unit budget.
+// A non-positive budget disables the budget check entirely (Allow always
+// returns true), for callers that would rather rely on the API's own quota
+// errors.
+func NewQuotaTracker(dailyBudget int) *QuotaTracker {
+	return &QuotaTracker{budget: dailyBudget, dayStart: startOfUTCDay(time.Now())}
+}
+
+// Allow reports whether a call costing cost units fits within today's
+// remaining budget. If it doesn't, ok is false and resetAt is when the
+// budget next resets.
+func (q *QuotaTracker) Allow(cost int) (ok bool, resetAt time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rolloverIfNewDay()
+	if q.budget <= 0 {
+		return true, time.Time{}
+	}
+	if q.used+cost > q.budget {
+		return false, q.dayStart.Add(24 * time.Hour)
+	}
+	return true, time.Time{}
+}
+
+// Record accounts for a call that already happened.
+func (q *QuotaTracker) Record(cost int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rolloverIfNewDay()
+	q.used += cost
+}
+
+// Remaining returns the units left in today's budget, for logging.
+func (q *QuotaTracker) Remaining() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rolloverIfNewDay()
+	if q.budget <= 0 {
+		return q.budget
+	}
+	return q.budget - q.used
+}
+
+func (q *QuotaTracker) rolloverIfNewDay() {
+	now := time.Now()
+	if !now.Before(q.dayStart.Add(24 * time.Hour)) {
+		q.dayStart = startOfUTCDay(now)
+		q.used = 0
+	}
+}
+
+func startOfUTCDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
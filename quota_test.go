@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuotaTracker_AllowWithinBudget(t *testing.T) {
+	q := NewQuotaTracker(100)
+
+	if ok, _ := q.Allow(60); !ok {
+		t.Fatal("Allow(60) = false, want true with 100 units free")
+	}
+	q.Record(60)
+
+	if ok, _ := q.Allow(40); !ok {
+		t.Fatal("Allow(40) = false, want true with 40 units left")
+	}
+}
+
+func TestQuotaTracker_AllowOverBudget(t *testing.T) {
+	q := NewQuotaTracker(100)
+	q.Record(90)
+
+	ok, resetAt := q.Allow(20)
+	if ok {
+		t.Fatal("Allow(20) = true, want false with only 10 units left")
+	}
+	if !resetAt.Equal(q.dayStart.Add(24 * time.Hour)) {
+		t.Errorf("resetAt = %v, want %v", resetAt, q.dayStart.Add(24*time.Hour))
+	}
+}
+
+func TestQuotaTracker_NonPositiveBudgetDisablesCheck(t *testing.T) {
+	q := NewQuotaTracker(0)
+
+	q.Record(1_000_000)
+	if ok, _ := q.Allow(1_000_000); !ok {
+		t.Fatal("Allow = false with non-positive budget, want true (budget check disabled)")
+	}
+	if got := q.Remaining(); got != 0 {
+		t.Errorf("Remaining() = %d, want 0 (budget itself, unchanged)", got)
+	}
+}
+
+func TestQuotaTracker_RollsOverAtNextUTCDay(t *testing.T) {
+	q := NewQuotaTracker(100)
+	q.Record(100)
+	if ok, _ := q.Allow(1); ok {
+		t.Fatal("Allow(1) = true right after exhausting today's budget, want false")
+	}
+
+	// Simulate the day boundary passing without waiting for it: back-date
+	// dayStart by a full day so rolloverIfNewDay sees now as past it.
+	q.mu.Lock()
+	q.dayStart = q.dayStart.Add(-24 * time.Hour)
+	q.mu.Unlock()
+
+	if got := q.Remaining(); got != 100 {
+		t.Errorf("Remaining() after day rollover = %d, want 100 (used reset to 0)", got)
+	}
+	if ok, _ := q.Allow(100); !ok {
+		t.Fatal("Allow(100) = false right after day rollover, want true")
+	}
+
+	want := startOfUTCDay(time.Now())
+	if !q.dayStart.Equal(want) {
+		t.Errorf("dayStart after rollover = %v, want %v", q.dayStart, want)
+	}
+}
+
+func TestStartOfUTCDay(t *testing.T) {
+	in := time.Date(2026, time.July, 25, 13, 45, 6, 7, time.FixedZone("UTC+9", 9*3600))
+	want := time.Date(2026, time.July, 25, 0, 0, 0, 0, time.UTC)
+
+	if got := startOfUTCDay(in); !got.Equal(want) {
+		t.Errorf("startOfUTCDay(%v) = %v, want %v", in, got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nareix/joy4/format/rtmp"
+)
+
+// RTMPDetector is a StreamDetector for users who stream through a local
+// relay (e.g. OBS pushing to an nginx-rtmp or MediaMTX server running on
+// the same machine) instead of directly to a cloud platform. It probes the
+// RTMP endpoint as a player would: a publisher is considered live if we can
+// connect and read its stream header.
+type RTMPDetector struct {
+	url           string
+	checkInterval time.Duration
+}
+
+// NewRTMPDetector creates an RTMPDetector for the given rtmp:// ingest URL,
+// polling every checkInterval.
+func NewRTMPDetector(url string, checkInterval time.Duration) *RTMPDetector {
+	return &RTMPDetector{url: url, checkInterval: checkInterval}
+}
+
+// Start polls the RTMP endpoint for a live publisher.
+func (d *RTMPDetector) Start(ctx context.Context) <-chan StreamEvent {
+	eventCh := make(chan StreamEvent)
+
+	go func() {
+		ticker := time.NewTicker(d.checkInterval)
+		defer ticker.Stop()
+
+		checkAndUpdateStatus := func() {
+			isLive, err := d.probe()
+			if err != nil {
+				log.Printf("Error probing RTMP endpoint: %v", err)
+				isLive = false
+			}
+
+			event := StreamEvent{Platform: PlatformRTMP, IsLive: isLive}
+			if isLive {
+				log.Println("RTMP ingest is live")
+			} else {
+				log.Println("No active RTMP publisher found")
+			}
+
+			select {
+			case eventCh <- event:
+			case <-ctx.Done():
+				return
+			case <-time.After(10 * time.Second):
+				log.Println("timed out to send RTMP stream status to channel")
+			}
+		}
+
+		checkAndUpdateStatus()
+
+		for {
+			select {
+			case <-ticker.C:
+				checkAndUpdateStatus()
+			case <-ctx.Done():
+				close(eventCh)
+				return
+			}
+		}
+	}()
+
+	return eventCh
+}
+
+// probe dials the RTMP endpoint as a player and tries to read the stream
+// header; this only succeeds while a publisher is actively pushing.
+func (d *RTMPDetector) probe() (bool, error) {
+	conn, err := rtmp.Dial(d.url)
+	if err != nil {
+		// Connection refused / no such app is the common "nobody's
+		// publishing yet" case, not a detector error.
+		return false, nil
+	}
+	defer conn.Close()
+
+	conn.NetConn().SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := conn.Streams(); err != nil {
+		return false, fmt.Errorf("failed to read RTMP stream headers: %w", err)
+	}
+
+	return true, nil
+}
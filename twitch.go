@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	twitchOAuthTokenURL = "https://id.twitch.tv/oauth2/token"
+	twitchStreamsURL    = "https://api.twitch.tv/helix/streams"
+)
+
+// TwitchDetector is a StreamDetector backed by the Twitch Helix "streams"
+// endpoint, authenticated with an app access token (client credentials
+// grant). It needs no user login since it only reads public stream state.
+type TwitchDetector struct {
+	clientID      string
+	clientSecret  string
+	userLogin     string
+	checkInterval time.Duration
+
+	httpClient *http.Client
+	token      string
+	tokenExp   time.Time
+}
+
+// NewTwitchDetector creates a TwitchDetector for the given Twitch login
+// name (not display name), polling every checkInterval.
+func NewTwitchDetector(clientID, clientSecret, userLogin string, checkInterval time.Duration) *TwitchDetector {
+	return &TwitchDetector{
+		clientID:      clientID,
+		clientSecret:  clientSecret,
+		userLogin:     userLogin,
+		checkInterval: checkInterval,
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Start polls the Twitch Helix API for the configured user's live status.
+func (d *TwitchDetector) Start(ctx context.Context) <-chan StreamEvent {
+	eventCh := make(chan StreamEvent)
+
+	go func() {
+		ticker := time.NewTicker(d.checkInterval)
+		defer ticker.Stop()
+
+		checkAndUpdateStatus := func() {
+			apiCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+
+			event, err := d.checkStream(apiCtx)
+			if err != nil {
+				log.Printf("Error checking Twitch stream status: %v", err)
+				return
+			}
+
+			select {
+			case eventCh <- event:
+			case <-ctx.Done():
+				return
+			case <-time.After(10 * time.Second):
+				log.Println("timed out to send Twitch stream status to channel")
+			}
+		}
+
+		checkAndUpdateStatus()
+
+		for {
+			select {
+			case <-ticker.C:
+				checkAndUpdateStatus()
+			case <-ctx.Done():
+				close(eventCh)
+				return
+			}
+		}
+	}()
+
+	return eventCh
+}
+
+// checkStream fetches an app access token (refreshing it if needed) and
+// queries the Helix streams endpoint for the configured user.
+func (d *TwitchDetector) checkStream(ctx context.Context) (StreamEvent, error) {
+	if err := d.ensureAppToken(ctx); err != nil {
+		return StreamEvent{}, fmt.Errorf("failed to get Twitch app token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, twitchStreamsURL+"?user_login="+url.QueryEscape(d.userLogin), nil)
+	if err != nil {
+		return StreamEvent{}, fmt.Errorf("failed to build Helix streams request: %w", err)
+	}
+	req.Header.Set("Client-Id", d.clientID)
+	req.Header.Set("Authorization", "Bearer "+d.token)
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return StreamEvent{}, fmt.Errorf("failed to call Helix streams endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return StreamEvent{}, fmt.Errorf("Helix streams endpoint returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data []struct {
+			Title       string `json:"title"`
+			ViewerCount int    `json:"viewer_count"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return StreamEvent{}, fmt.Errorf("failed to decode Helix streams response: %w", err)
+	}
+
+	event := StreamEvent{Platform: PlatformTwitch, IsLive: len(body.Data) > 0}
+	if event.IsLive {
+		event.Title = body.Data[0].Title
+		event.ViewerCount = body.Data[0].ViewerCount
+		log.Printf("Twitch stream is live: %s", event.Title)
+	} else {
+		log.Println("No active Twitch stream found")
+	}
+
+	return event, nil
+}
+
+// ensureAppToken fetches a new app access token if we don't have one or it
+// has expired.
+func (d *TwitchDetector) ensureAppToken(ctx context.Context) error {
+	if d.token != "" && time.Now().Before(d.tokenExp) {
+		return nil
+	}
+
+	form := url.Values{
+		"client_id":     {d.clientID},
+		"client_secret": {d.clientSecret},
+		"grant_type":    {"client_credentials"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, twitchOAuthTokenURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.URL.RawQuery = form.Encode()
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request app token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	d.token = body.AccessToken
+	// Refresh a minute early to avoid racing the expiry.
+	d.tokenExp = time.Now().Add(time.Duration(body.ExpiresIn)*time.Second - time.Minute)
+	return nil
+}
@@ -1,8 +1,9 @@
+//go:build windows
+
 package main
 
 import (
-	"fmt"
-	"unsafe"
+	"syscall"
 
 	"github.com/lxn/win"
 	"golang.org/x/sys/windows"
@@ -21,6 +22,7 @@ var (
 	// Import required functions from user32.dll
 	user32                         = windows.NewLazyDLL("user32.dll")
 	procSetLayeredWindowAttributes = user32.NewProc("SetLayeredWindowAttributes")
+	procEnumDisplayMonitors        = user32.NewProc("EnumDisplayMonitors")
 
 	// Import required functions from gdi32.dll
 	gdi32                = windows.NewLazyDLL("gdi32.dll")
@@ -58,36 +60,15 @@ func PatBlt(hdc win.HDC, x, y, width, height int, rop uint32) bool {
 	return ret != 0
 }
 
-// ShellExecute wraps the Windows API function to open URLs
-var shell32 = windows.NewLazyDLL("shell32.dll")
-var procShellExecute = shell32.NewProc("ShellExecuteW")
-
-// OpenURL opens a URL in the default browser
-func OpenURL(url string) error {
-	verb := "open"
-	lpFile := url
-
-	verbPtr, err := windows.UTF16PtrFromString(verb)
-	if err != nil {
-		return fmt.Errorf("failed to convert verb to UTF16: %w", err)
-	}
-
-	lpFilePtr, err := windows.UTF16PtrFromString(lpFile)
-	if err != nil {
-		return fmt.Errorf("failed to convert URL to UTF16: %w", err)
-	}
-
-	ret, _, _ := procShellExecute.Call(
-		uintptr(0),
-		uintptr(unsafe.Pointer(verbPtr)),
-		uintptr(unsafe.Pointer(lpFilePtr)),
-		uintptr(0),
-		uintptr(0),
-		uintptr(1), // SW_SHOWNORMAL
-	)
-
-	if ret <= 32 {
-		return fmt.Errorf("failed to open URL: %w", windows.GetLastError())
-	}
-	return nil
+// EnumDisplayMonitors wraps the Windows API function, calling fn once per
+// active monitor with its handle and bounding rectangle. fn should return
+// true to keep enumerating.
+func EnumDisplayMonitors(fn func(hMonitor win.HMONITOR, rcMonitor win.RECT) bool) {
+	callback := syscall.NewCallback(func(hMonitor win.HMONITOR, _ win.HDC, lprcMonitor *win.RECT, _ uintptr) uintptr {
+		if fn(hMonitor, *lprcMonitor) {
+			return 1
+		}
+		return 0
+	})
+	procEnumDisplayMonitors.Call(0, 0, callback, 0)
 }
@@ -2,78 +2,194 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
+	"math/rand"
 	"net/http"
 	"time"
 
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 )
 
-// IsLiveStreaming checks if the user is currently live streaming on YouTube
-func IsLiveStreaming(ctx context.Context, client *http.Client, checkInterval time.Duration) chan bool {
-	statusCh := make(chan bool)
+// liveBroadcastsListCost is the YouTube Data API quota cost, in units, of
+// one LiveBroadcasts.List call.
+const liveBroadcastsListCost = 100
 
-	// Create YouTube service
-	youtubeService, err := youtube.NewService(ctx, option.WithHTTPClient(client))
+// YouTubeDetector is a StreamDetector backed by the YouTube Data API's
+// LiveBroadcasts.List endpoint. It polls at checkInterval while healthy,
+// backs off exponentially (full jitter, capped at maxInterval) on
+// quota/rate-limit/5xx errors, and refuses to poll at all once quota's
+// daily budget is exhausted.
+type YouTubeDetector struct {
+	client         *http.Client
+	checkInterval  time.Duration
+	maxInterval    time.Duration
+	backoffOnError bool
+	quota          *QuotaTracker
+}
+
+// NewYouTubeDetector creates a YouTubeDetector that polls every
+// checkInterval, backing off up to maxInterval on quota/rate-limit/5xx
+// errors if backoffOnError is set, and tracking usage against quota.
+func NewYouTubeDetector(client *http.Client, checkInterval, maxInterval time.Duration, backoffOnError bool, quota *QuotaTracker) *YouTubeDetector {
+	return &YouTubeDetector{
+		client:         client,
+		checkInterval:  checkInterval,
+		maxInterval:    maxInterval,
+		backoffOnError: backoffOnError,
+		quota:          quota,
+	}
+}
+
+// Start checks if the user is currently live streaming on YouTube.
+func (d *YouTubeDetector) Start(ctx context.Context) <-chan StreamEvent {
+	eventCh := make(chan StreamEvent)
+
+	youtubeService, err := youtube.NewService(ctx, option.WithHTTPClient(d.client))
 	if err != nil {
-		log.Fatalf("Error creating YouTube service: %v", err)
+		slog.Error("Error creating YouTube service", "error", err)
+		close(eventCh)
+		return eventCh
 	}
 
-	// Start goroutine to check streaming status periodically
 	go func() {
-		ticker := time.NewTicker(checkInterval)
-		defer ticker.Stop()
-
-		// Function to check streaming status and send update
-		checkAndUpdateStatus := func() {
-			// Check for live broadcasts with timeout context
-			apiCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-			defer cancel()
-
-			searchResponse, err := youtubeService.LiveBroadcasts.List([]string{"snippet", "id"}).
-				BroadcastStatus("active").
-				Context(apiCtx).
-				Do()
-			if err != nil {
-				log.Printf("Error checking live broadcasts: %v", err)
+		defer close(eventCh)
+
+		interval := d.checkInterval
+		attempt := 0
+		haveLast, lastIsLive := false, false
+
+		timer := time.NewTimer(0) // immediate first check
+		defer timer.Stop()
 
-				// Add a small delay before the next check on error
-				time.Sleep(1 * time.Second)
+		for {
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
 				return
 			}
 
-			isLive := len(searchResponse.Items) > 0
+			if ok, resetAt := d.quota.Allow(liveBroadcastsListCost); !ok {
+				wait := time.Until(resetAt)
+				if wait <= 0 {
+					wait = d.checkInterval
+				}
+				slog.Warn("YouTube daily quota budget exhausted, pausing polling",
+					"resets_at", resetAt, "next_delay", wait)
+				timer.Reset(wait)
+				continue
+			}
+
+			event, err := d.checkOnce(ctx, youtubeService)
+			d.quota.Record(liveBroadcastsListCost)
+
+			if err != nil {
+				if d.backoffOnError && isQuotaOrServerError(err) {
+					attempt++
+					interval = d.backoffDelay(attempt)
+					slog.Warn("YouTube check failed with quota/server error, backing off",
+						"attempt", attempt, "next_delay", interval, "remaining_quota", d.quota.Remaining(), "error", err)
+				} else {
+					slog.Warn("YouTube check failed, retrying at current interval",
+						"next_delay", interval, "remaining_quota", d.quota.Remaining(), "error", err)
+				}
+				timer.Reset(interval)
+				continue
+			}
 
-			if isLive {
-				log.Printf("Stream is live: %s", searchResponse.Items[0].Snippet.Title)
+			if event.IsLive {
+				slog.Info("Stream is live", "title", event.Title)
 			} else {
-				log.Printf("No active stream found")
+				slog.Info("No active stream found")
 			}
 
-			// Send the status to the channel
+			transitioned := !haveLast || event.IsLive != lastIsLive
+			haveLast, lastIsLive = true, event.IsLive
+
 			select {
-			case statusCh <- isLive:
+			case eventCh <- event:
 			case <-ctx.Done():
 				return
 			case <-time.After(10 * time.Second):
-				log.Println("timed out to send stream status to channel")
+				slog.Warn("timed out sending stream status to channel")
 			}
-		}
-
-		// Perform an immediate check when starting
-		checkAndUpdateStatus()
 
-		for {
-			select {
-			case <-ticker.C:
-				checkAndUpdateStatus()
-			case <-ctx.Done():
-				close(statusCh)
-				return
+			attempt = 0
+			if transitioned {
+				interval = d.checkInterval
+				slog.Info("Stream status changed, rechecking immediately", "remaining_quota", d.quota.Remaining())
+				timer.Reset(0)
+				continue
 			}
+
+			slog.Info("YouTube check scheduled", "next_delay", interval, "remaining_quota", d.quota.Remaining())
+			timer.Reset(interval)
 		}
 	}()
 
-	return statusCh
+	return eventCh
+}
+
+// checkOnce performs a single LiveBroadcasts.List call and turns its result
+// into a StreamEvent.
+func (d *YouTubeDetector) checkOnce(ctx context.Context, svc *youtube.Service) (StreamEvent, error) {
+	apiCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := svc.LiveBroadcasts.List([]string{"snippet", "id"}).
+		BroadcastStatus("active").
+		Context(apiCtx).
+		Do()
+	if err != nil {
+		return StreamEvent{}, err
+	}
+
+	event := StreamEvent{Platform: PlatformYouTube, IsLive: len(resp.Items) > 0}
+	if event.IsLive {
+		event.Title = resp.Items[0].Snippet.Title
+	}
+	return event, nil
+}
+
+// backoffDelay returns the delay for the given 1-indexed attempt number,
+// using exponential backoff with full jitter, capped at maxInterval (or
+// checkInterval if maxInterval isn't set).
+func (d *YouTubeDetector) backoffDelay(attempt int) time.Duration {
+	ceiling := d.maxInterval
+	if ceiling <= 0 {
+		ceiling = d.checkInterval
+	}
+
+	backoff := d.checkInterval * time.Duration(uint64(1)<<uint(attempt-1))
+	if backoff <= 0 || backoff > ceiling {
+		backoff = ceiling
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(backoff)) + 1)
+}
+
+// isQuotaOrServerError reports whether err is a YouTube Data API 403
+// quotaExceeded/rateLimitExceeded error or a 5xx server error, the cases
+// worth backing off for rather than retrying at the normal interval.
+func isQuotaOrServerError(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if apiErr.Code >= 500 {
+		return true
+	}
+	if apiErr.Code == http.StatusForbidden {
+		for _, e := range apiErr.Errors {
+			if e.Reason == "quotaExceeded" || e.Reason == "rateLimitExceeded" {
+				return true
+			}
+		}
+	}
+	return false
 }
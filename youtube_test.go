@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestYouTubeDetector_BackoffDelay_CappedAtMaxInterval(t *testing.T) {
+	d := &YouTubeDetector{checkInterval: time.Second, maxInterval: 5 * time.Second}
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		delay := d.backoffDelay(attempt)
+		if delay <= 0 || delay > d.maxInterval {
+			t.Errorf("backoffDelay(%d) = %v, want in (0, %v]", attempt, delay, d.maxInterval)
+		}
+	}
+}
+
+func TestYouTubeDetector_BackoffDelay_FallsBackToCheckIntervalWhenMaxIntervalUnset(t *testing.T) {
+	for _, maxInterval := range []time.Duration{0, -time.Second} {
+		d := &YouTubeDetector{checkInterval: time.Second, maxInterval: maxInterval}
+
+		delay := d.backoffDelay(1)
+		if delay <= 0 || delay > d.checkInterval {
+			t.Errorf("backoffDelay(1) with maxInterval=%v = %v, want in (0, %v]", maxInterval, delay, d.checkInterval)
+		}
+	}
+}
+
+func TestYouTubeDetector_BackoffDelay_OverflowSafeAtHighAttempts(t *testing.T) {
+	d := &YouTubeDetector{checkInterval: time.Second, maxInterval: time.Minute}
+
+	// 1<<(attempt-1) overflows uint64 well before attempt=100; backoffDelay
+	// must still return a sane, capped delay rather than panicking or
+	// wrapping around to a tiny or negative duration.
+	for _, attempt := range []int{63, 64, 65, 100} {
+		delay := d.backoffDelay(attempt)
+		if delay <= 0 || delay > d.maxInterval {
+			t.Errorf("backoffDelay(%d) = %v, want in (0, %v]", attempt, delay, d.maxInterval)
+		}
+	}
+}
+
+func TestYouTubeDetector_BackoffDelay_ZeroCheckIntervalAndMaxInterval(t *testing.T) {
+	d := &YouTubeDetector{checkInterval: 0, maxInterval: 0}
+
+	if delay := d.backoffDelay(1); delay != 0 {
+		t.Errorf("backoffDelay(1) with checkInterval=maxInterval=0 = %v, want 0", delay)
+	}
+}